@@ -0,0 +1,39 @@
+package announcements
+
+import "time"
+
+const (
+	// CertificateIssued is announced when a new certificate is issued for a CN that had none
+	// cached.
+	CertificateIssued Kind = "certificate-issued"
+
+	// CertificateRotated is announced when an existing certificate is replaced with a new one
+	// for the same CN.
+	CertificateRotated Kind = "certificate-rotated"
+
+	// CertificateReleased is announced when a certificate is explicitly released, or evicted
+	// from the certificate cache.
+	CertificateReleased Kind = "certificate-released"
+
+	// CertificateRotationFailed is announced when a scheduled rotation fails to issue a
+	// replacement certificate.
+	CertificateRotationFailed Kind = "certificate-rotation-failed"
+
+	// CertificateNearExpiry is announced when a certificate has entered its renewal window but
+	// has not yet been rotated.
+	CertificateNearExpiry Kind = "certificate-near-expiry"
+)
+
+// CertificateEvent carries the detail for a certificate lifecycle Announcement. It is attached as
+// Announcement.NewObj, with Announcement.OldObj left nil except for CertificateRotated, where it
+// holds the CertificateEvent for the certificate being replaced.
+type CertificateEvent struct {
+	// CN is the common name of the certificate the event concerns.
+	CN string
+
+	// SerialNumber is the serial number assigned by the issuer.
+	SerialNumber string
+
+	// Expiration is when the certificate expires (or expired, for CertificateRotationFailed).
+	Expiration time.Time
+}
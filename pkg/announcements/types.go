@@ -0,0 +1,20 @@
+// Package announcements defines the event type certificate.Manager and other OSM control plane
+// components use to signal that something the mesh cares about has changed.
+package announcements
+
+// Kind identifies the nature of an Announcement.
+type Kind string
+
+// Announcement is dispatched over a component's announcements channel whenever state it owns
+// changes and downstream watchers (e.g. the proxy broadcaster) need to react.
+type Announcement struct {
+	// Type identifies what kind of change this Announcement represents. Empty for components
+	// that haven't been migrated to typed announcements yet.
+	Type Kind
+
+	// OldObj is the object's state before the change, when applicable.
+	OldObj interface{}
+
+	// NewObj is the object's state after the change, when applicable.
+	NewObj interface{}
+}
@@ -0,0 +1,253 @@
+package certificate
+
+import (
+	"sync"
+	"time"
+)
+
+// EvictionPolicy selects how Cache picks a victim once it is over capacity.
+type EvictionPolicy string
+
+const (
+	// EvictionPolicyLRU evicts the least-recently-used certificate.
+	EvictionPolicyLRU EvictionPolicy = "lru"
+
+	// EvictionPolicyLFU evicts the least-frequently-used certificate.
+	EvictionPolicyLFU EvictionPolicy = "lfu"
+)
+
+// DefaultCacheCapacity is used when CacheOptions.Capacity is zero, i.e. left unset.
+const DefaultCacheCapacity = 10000
+
+// CacheOptions configures a Cache's size bound, eviction strategy, and entry TTL. It is
+// re-appliable at runtime via Cache.SetOptions.
+type CacheOptions struct {
+	// Capacity is the maximum number of certificates the cache holds before it starts evicting.
+	// A value <= 0 is treated as DefaultCacheCapacity.
+	Capacity int
+
+	// EvictionPolicy picks the victim once the cache is over Capacity. Defaults to
+	// EvictionPolicyLRU.
+	EvictionPolicy EvictionPolicy
+
+	// TTL, when positive, expires cache entries this long after they were stored, regardless of
+	// how often they're accessed. A zero value disables TTL-based expiry.
+	TTL time.Duration
+}
+
+func (o CacheOptions) capacity() int {
+	if o.Capacity <= 0 {
+		return DefaultCacheCapacity
+	}
+	return o.Capacity
+}
+
+func (o CacheOptions) evictionPolicy() EvictionPolicy {
+	if o.EvictionPolicy == "" {
+		return EvictionPolicyLRU
+	}
+	return o.EvictionPolicy
+}
+
+type cacheEntry struct {
+	cert       Certificater
+	storedAt   time.Time
+	lastAccess time.Time
+	hits       int
+}
+
+// Cache is a bounded, evictable store of Certificater values keyed by CommonName. It is safe for
+// concurrent use, supports LRU or LFU eviction once over capacity, and lets operators change its
+// capacity, eviction policy, or TTL at runtime via SetOptions without restarting the control
+// plane. It is intentionally provider-agnostic so any certificate.Manager implementation can wrap
+// one; only the Vault CertManager does so today.
+type Cache struct {
+	mu      sync.RWMutex
+	entries map[CommonName]*cacheEntry
+
+	optionsMu sync.RWMutex
+	options   CacheOptions
+
+	// onEvict, when set, is called with every certificate removed by capacity or TTL eviction
+	// (but not by an explicit Remove/RemoveMatching call) so callers can announce the change.
+	onEvict func(CommonName, Certificater)
+}
+
+// NewCache returns an empty Cache configured with options. onEvict may be nil.
+func NewCache(options CacheOptions, onEvict func(cn CommonName, cert Certificater)) *Cache {
+	return &Cache{
+		entries: make(map[CommonName]*cacheEntry),
+		options: options,
+		onEvict: onEvict,
+	}
+}
+
+// SetOptions updates the cache's capacity, eviction policy, and TTL at runtime. A shrunk capacity
+// triggers eviction immediately rather than waiting for the next Store.
+func (c *Cache) SetOptions(options CacheOptions) {
+	c.optionsMu.Lock()
+	c.options = options
+	c.optionsMu.Unlock()
+
+	c.mu.Lock()
+	evicted := c.evictLocked()
+	c.mu.Unlock()
+
+	c.announceEvictions(evicted)
+}
+
+func (c *Cache) currentOptions() CacheOptions {
+	c.optionsMu.RLock()
+	defer c.optionsMu.RUnlock()
+	return c.options
+}
+
+// Store adds or replaces the certificate for cn, then evicts entries over capacity or TTL.
+func (c *Cache) Store(cn CommonName, cert Certificater) {
+	now := time.Now()
+
+	c.mu.Lock()
+	c.entries[cn] = &cacheEntry{cert: cert, storedAt: now, lastAccess: now}
+	evicted := c.evictLocked()
+	c.mu.Unlock()
+
+	c.announceEvictions(evicted)
+}
+
+// Load returns the cached certificate for cn, if present and not TTL-expired.
+func (c *Cache) Load(cn CommonName) (Certificater, bool) {
+	c.mu.Lock()
+
+	entry, exists := c.entries[cn]
+	if !exists {
+		c.mu.Unlock()
+		return nil, false
+	}
+
+	if ttl := c.currentOptions().TTL; ttl > 0 && time.Since(entry.storedAt) > ttl {
+		delete(c.entries, cn)
+		c.mu.Unlock()
+		c.announceEvictions([]evictedEntry{{cn, entry.cert}})
+		return nil, false
+	}
+
+	entry.lastAccess = time.Now()
+	entry.hits++
+	cert := entry.cert
+	c.mu.Unlock()
+
+	return cert, true
+}
+
+// Remove deletes cn from the cache, if present. Unlike capacity/TTL eviction, this does not
+// invoke onEvict: the caller already knows the certificate is gone (e.g. ReleaseCertificate).
+func (c *Cache) Remove(cn CommonName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, cn)
+}
+
+// RemoveMatching removes every cached certificate for which match returns true, e.g. to evict all
+// certificates issued by a CA that is being rotated out.
+func (c *Cache) RemoveMatching(match func(Certificater) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for cn, entry := range c.entries {
+		if match(entry.cert) {
+			delete(c.entries, cn)
+		}
+	}
+}
+
+// List returns every certificate currently in the cache, in no particular order.
+func (c *Cache) List() []Certificater {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	certs := make([]Certificater, 0, len(c.entries))
+	for _, entry := range c.entries {
+		certs = append(certs, entry.cert)
+	}
+	return certs
+}
+
+// Len returns the number of certificates currently cached.
+func (c *Cache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// evictedEntry records a certificate removed by capacity or TTL eviction, queued for onEvict to
+// be called once the caller has released c.mu.
+type evictedEntry struct {
+	cn   CommonName
+	cert Certificater
+}
+
+// evictLocked removes TTL-expired entries, then evicts down to capacity using the configured
+// eviction policy, returning everything it removed. Callers must hold c.mu, and must call
+// announceEvictions with the result only after releasing it.
+func (c *Cache) evictLocked() []evictedEntry {
+	var evicted []evictedEntry
+	options := c.currentOptions()
+
+	if options.TTL > 0 {
+		now := time.Now()
+		for cn, entry := range c.entries {
+			if now.Sub(entry.storedAt) > options.TTL {
+				delete(c.entries, cn)
+				evicted = append(evicted, evictedEntry{cn, entry.cert})
+			}
+		}
+	}
+
+	capacity := options.capacity()
+	for len(c.entries) > capacity {
+		victim, exists := c.pickVictimLocked(options.evictionPolicy())
+		if !exists {
+			break
+		}
+		cert := c.entries[victim].cert
+		delete(c.entries, victim)
+		evicted = append(evicted, evictedEntry{victim, cert})
+	}
+
+	return evicted
+}
+
+// pickVictimLocked picks the cache's eviction victim under policy. Map iteration order is random,
+// so ties must be broken on something other than "whichever entry is visited first": LFU breaks
+// ties on lastAccess (oldest access among equally-hit entries loses), matching LRU's own ordering.
+func (c *Cache) pickVictimLocked(policy EvictionPolicy) (CommonName, bool) {
+	var victim CommonName
+	found := false
+	var worst time.Time
+	var worstHits int
+
+	for cn, entry := range c.entries {
+		switch policy {
+		case EvictionPolicyLFU:
+			if !found || entry.hits < worstHits || (entry.hits == worstHits && entry.lastAccess.Before(worst)) {
+				victim, worst, worstHits, found = cn, entry.lastAccess, entry.hits, true
+			}
+		default: // EvictionPolicyLRU
+			if !found || entry.lastAccess.Before(worst) {
+				victim, worst, found = cn, entry.lastAccess, true
+			}
+		}
+	}
+	return victim, found
+}
+
+// announceEvictions invokes onEvict for each entry. Callers must NOT hold c.mu: onEvict is
+// provider-supplied and may itself publish an announcement, which can block.
+func (c *Cache) announceEvictions(evicted []evictedEntry) {
+	if c.onEvict == nil {
+		return
+	}
+	for _, e := range evicted {
+		c.onEvict(e.cn, e.cert)
+	}
+}
@@ -0,0 +1,121 @@
+package certificate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeCertificate is a minimal Certificater used only to exercise Cache; its fields carry no
+// semantic meaning beyond identifying which certificate a test is looking at.
+type fakeCertificate struct {
+	cn     CommonName
+	serial string
+}
+
+func (f fakeCertificate) GetCommonName() CommonName   { return f.cn }
+func (f fakeCertificate) GetCertificateChain() []byte { return nil }
+func (f fakeCertificate) GetPrivateKey() []byte       { return nil }
+func (f fakeCertificate) GetIssuingCA() []byte        { return nil }
+func (f fakeCertificate) GetExpiration() time.Time    { return time.Time{} }
+func (f fakeCertificate) GetSerialNumber() string     { return f.serial }
+
+func TestCacheStoreAndLoad(t *testing.T) {
+	c := NewCache(CacheOptions{}, nil)
+
+	_, exists := c.Load("not-there")
+	assert.False(t, exists)
+
+	cert := fakeCertificate{cn: "a.example.com", serial: "1"}
+	c.Store(cert.cn, cert)
+
+	loaded, exists := c.Load(cert.cn)
+	assert.True(t, exists)
+	assert.Equal(t, cert, loaded)
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestCacheLRUEviction(t *testing.T) {
+	var evicted []CommonName
+	c := NewCache(CacheOptions{Capacity: 2, EvictionPolicy: EvictionPolicyLRU}, func(cn CommonName, _ Certificater) {
+		evicted = append(evicted, cn)
+	})
+
+	c.Store("a", fakeCertificate{cn: "a", serial: "1"})
+	c.Store("b", fakeCertificate{cn: "b", serial: "1"})
+
+	// Touch "a" so it is more recently used than "b".
+	_, _ = c.Load("a")
+
+	// Over capacity: "b" is the least-recently-used entry and should be evicted.
+	c.Store("c", fakeCertificate{cn: "c", serial: "1"})
+
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, []CommonName{"b"}, evicted)
+
+	_, exists := c.Load("b")
+	assert.False(t, exists)
+}
+
+func TestCacheLFUEviction(t *testing.T) {
+	var evicted []CommonName
+	c := NewCache(CacheOptions{Capacity: 2, EvictionPolicy: EvictionPolicyLFU}, func(cn CommonName, _ Certificater) {
+		evicted = append(evicted, cn)
+	})
+
+	c.Store("a", fakeCertificate{cn: "a", serial: "1"})
+	c.Store("b", fakeCertificate{cn: "b", serial: "1"})
+
+	// Hit "a" several times so it is more frequently used than "b".
+	_, _ = c.Load("a")
+	_, _ = c.Load("a")
+	_, _ = c.Load("a")
+
+	c.Store("c", fakeCertificate{cn: "c", serial: "1"})
+
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, []CommonName{"b"}, evicted)
+}
+
+func TestCacheTTLExpiry(t *testing.T) {
+	var evicted []CommonName
+	c := NewCache(CacheOptions{TTL: time.Millisecond}, func(cn CommonName, _ Certificater) {
+		evicted = append(evicted, cn)
+	})
+
+	c.Store("a", fakeCertificate{cn: "a", serial: "1"})
+	time.Sleep(5 * time.Millisecond)
+
+	_, exists := c.Load("a")
+	assert.False(t, exists)
+	assert.Equal(t, []CommonName{"a"}, evicted)
+}
+
+func TestCacheRemoveDoesNotInvokeOnEvict(t *testing.T) {
+	called := false
+	c := NewCache(CacheOptions{}, func(CommonName, Certificater) {
+		called = true
+	})
+
+	c.Store("a", fakeCertificate{cn: "a", serial: "1"})
+	c.Remove("a")
+
+	assert.Equal(t, 0, c.Len())
+	assert.False(t, called)
+}
+
+func TestCacheSetOptionsEvictsImmediately(t *testing.T) {
+	var evicted []CommonName
+	c := NewCache(CacheOptions{Capacity: 10}, func(cn CommonName, _ Certificater) {
+		evicted = append(evicted, cn)
+	})
+
+	c.Store("a", fakeCertificate{cn: "a", serial: "1"})
+	c.Store("b", fakeCertificate{cn: "b", serial: "1"})
+
+	c.SetOptions(CacheOptions{Capacity: 1})
+
+	assert.Equal(t, 1, c.Len())
+	assert.Len(t, evicted, 1)
+}
@@ -0,0 +1,35 @@
+// Package metrics exposes Prometheus metrics for certificate issuance, rotation, and caching, so
+// operators get the same "days-until-expiry" alerting surface they'd otherwise have to get by
+// inspecting Vault (or whichever issuer is in use) directly.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// CertExpirySeconds reports the Unix timestamp, in seconds, at which a certificate expires.
+// Alert on `time() > osm_cert_expiry_timestamp_seconds - <lead time>`.
+var CertExpirySeconds = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "osm_cert_expiry_timestamp_seconds",
+	Help: "Unix timestamp, in seconds, at which the certificate expires",
+}, []string{"cn", "serial", "issuer"})
+
+// CertIssueDurationSeconds observes how long a single certificate issuance took.
+var CertIssueDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "osm_cert_issue_duration_seconds",
+	Help:    "Time spent issuing a single certificate",
+	Buckets: prometheus.DefBuckets,
+}, []string{"issuer"})
+
+// CertIssueFailuresTotal counts certificate issuance failures by issuer and failure reason.
+var CertIssueFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "osm_cert_issue_failures_total",
+	Help: "Total number of certificate issuance failures",
+}, []string{"issuer", "reason"})
+
+// CertCacheSize reports the number of certificates currently held in a certificate.Cache.
+var CertCacheSize = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "osm_cert_cache_size",
+	Help: "Number of certificates currently in the certificate cache",
+})
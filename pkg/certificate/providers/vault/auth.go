@@ -0,0 +1,175 @@
+package vault
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultAppRoleMountPath    = "approle"
+	defaultKubernetesMountPath = "kubernetes"
+)
+
+// authenticate logs into Vault using the auth method selected on cfg and returns the *api.Secret
+// backing the resulting token, so the caller can start a lifetime watcher on it.
+func authenticate(client *api.Client, cfg VaultConfig) (*api.Secret, error) {
+	switch cfg.AuthMethod {
+	case AuthMethodAppRole:
+		return loginAppRole(client, cfg.AppRole)
+
+	case AuthMethodKubernetes:
+		return loginKubernetes(client, cfg.Kubernetes)
+
+	case AuthMethodToken, "":
+		if cfg.Token == "" {
+			return nil, errors.New("vault: token auth method selected but no token was provided")
+		}
+		client.SetToken(cfg.Token)
+		secret, err := client.Auth().Token().LookupSelf()
+		if err != nil {
+			return nil, errors.Wrap(err, "error looking up Vault token")
+		}
+		return tokenSecretFromLookup(cfg.Token, secret), nil
+
+	default:
+		return nil, errors.Errorf("vault: unsupported auth method %q", cfg.AuthMethod)
+	}
+}
+
+// tokenSecretFromLookup adapts the response of a token self-lookup into an *api.Secret shaped like
+// a login response. Unlike AppRole/Kubernetes login, LookupSelf carries the token's TTL and
+// renewable flag in Data rather than in an Auth block, so without this, watchAndRenewToken's
+// secret.Auth == nil check would always short-circuit and the plain-token path would never renew.
+func tokenSecretFromLookup(token string, lookup *api.Secret) *api.Secret {
+	renewable, _ := lookup.TokenIsRenewable()
+	ttl, _ := lookup.TokenTTL()
+
+	return &api.Secret{
+		Auth: &api.SecretAuth{
+			ClientToken:   token,
+			Renewable:     renewable,
+			LeaseDuration: int(ttl.Seconds()),
+		},
+	}
+}
+
+func loginAppRole(client *api.Client, auth *AppRoleAuth) (*api.Secret, error) {
+	if auth == nil {
+		return nil, errors.New("vault: approle auth method selected but no AppRoleAuth was configured")
+	}
+
+	mountPath := auth.MountPath
+	if mountPath == "" {
+		mountPath = defaultAppRoleMountPath
+	}
+
+	secret, err := client.Logical().Write(fmt.Sprintf("auth/%s/login", mountPath), map[string]interface{}{
+		"role_id":   auth.RoleID,
+		"secret_id": auth.SecretID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error logging into Vault via AppRole")
+	}
+	if secret == nil || secret.Auth == nil {
+		return nil, errors.New("vault: AppRole login returned no auth information")
+	}
+
+	client.SetToken(secret.Auth.ClientToken)
+	return secret, nil
+}
+
+func loginKubernetes(client *api.Client, auth *KubernetesAuth) (*api.Secret, error) {
+	if auth == nil {
+		return nil, errors.New("vault: kubernetes auth method selected but no KubernetesAuth was configured")
+	}
+
+	mountPath := auth.MountPath
+	if mountPath == "" {
+		mountPath = defaultKubernetesMountPath
+	}
+	tokenPath := auth.ServiceAccountTokenPath
+	if tokenPath == "" {
+		tokenPath = defaultServiceAccountTokenPath
+	}
+
+	jwt, err := ioutil.ReadFile(tokenPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading service account token from %s", tokenPath)
+	}
+
+	secret, err := client.Logical().Write(fmt.Sprintf("auth/%s/login", mountPath), map[string]interface{}{
+		"role": auth.Role,
+		"jwt":  string(jwt),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error logging into Vault via Kubernetes auth")
+	}
+	if secret == nil || secret.Auth == nil {
+		return nil, errors.New("vault: Kubernetes login returned no auth information")
+	}
+
+	client.SetToken(secret.Auth.ClientToken)
+	return secret, nil
+}
+
+// watchAndRenewToken starts a background goroutine that keeps the Vault client's token alive for
+// as long as possible, and transparently re-authenticates using the configured auth method once
+// the current lease can no longer be renewed. It is a no-op for tokens that are not renewable
+// (e.g. periodic root tokens used only in local development).
+func (cm *CertManager) watchAndRenewToken(secret *api.Secret) {
+	if secret == nil || secret.Auth == nil || !secret.Auth.Renewable {
+		return
+	}
+
+	go cm.renewalLoop(secret)
+}
+
+func (cm *CertManager) renewalLoop(secret *api.Secret) {
+	for {
+		watcher, err := cm.client.NewLifetimeWatcher(&api.LifetimeWatcherInput{Secret: secret})
+		if err != nil {
+			log.Error().Err(err).Msg("Error creating Vault token lifetime watcher; token renewal has stopped")
+			return
+		}
+
+		go watcher.Start()
+		done := cm.waitForRenewalOutcome(watcher)
+		watcher.Stop()
+
+		if !done {
+			continue
+		}
+
+		log.Warn().Msg("Vault token lease can no longer be renewed; re-authenticating")
+		newSecret, err := authenticate(cm.client, cm.vaultConfig)
+		if err != nil {
+			log.Error().Err(err).Msg("Error re-authenticating to Vault; token renewal has stopped")
+			return
+		}
+
+		secret = newSecret
+		if secret == nil || secret.Auth == nil || !secret.Auth.Renewable {
+			return
+		}
+	}
+}
+
+// waitForRenewalOutcome blocks on a single LifetimeWatcher until its lease is done, logging
+// renewals as they happen. It returns true once the watcher reports the lease is finished, at
+// which point the caller must re-authenticate and start a fresh watcher.
+func (cm *CertManager) waitForRenewalOutcome(watcher *api.LifetimeWatcher) bool {
+	for {
+		select {
+		case err := <-watcher.DoneCh():
+			if err != nil {
+				log.Error().Err(err).Msg("Vault token lifetime watcher exited with an error")
+			}
+			return true
+		case renewal := <-watcher.RenewCh():
+			log.Trace().Msgf("Vault token renewed at %s", renewal.RenewedAt)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/metrics"
 	"github.com/openservicemesh/osm/pkg/certificate/pem"
 	"github.com/openservicemesh/osm/pkg/certificate/rotor"
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -17,6 +18,10 @@ import (
 
 var log = logger.New("vault")
 
+// issuerLabel is the "issuer" label value this provider reports on shared certificate
+// metrics, so operators can distinguish Vault from other certificate.Manager implementations.
+const issuerLabel = "vault"
+
 const (
 	// The string value of the JSON key containing the certificate's Serial Number.
 	// See: https://www.vaultproject.io/api-docs/secret/pki#sample-response-8
@@ -29,26 +34,60 @@ const (
 
 	checkCertificateExpirationInterval = 5 * time.Second
 	tmpCertValidityPeriod              = 1 * time.Second
+
+	// issueBackoffBase and issueBackoffMax bound the exponential backoff applied to
+	// RotateCertificate retries after a failed issuance.
+	issueBackoffBase = 1 * time.Second
+	issueBackoffMax  = 1 * time.Minute
 )
 
 // NewCertManager implements certificate.Manager and wraps a Hashi Vault with methods to allow easy certificate issuance.
+// It authenticates to Vault with a static token; use NewCertManagerWithConfig for AppRole,
+// Kubernetes, or mTLS-based auth.
 func NewCertManager(vaultAddr, token string, role string, cfg configurator.Configurator) (*CertManager, error) {
+	return NewCertManagerWithConfig(VaultConfig{
+		Address:    vaultAddr,
+		Role:       role,
+		AuthMethod: AuthMethodToken,
+		Token:      token,
+	}, cfg)
+}
+
+// NewCertManagerWithConfig implements certificate.Manager and wraps a Hashi Vault with methods to
+// allow easy certificate issuance. Unlike NewCertManager, it supports the TLS and auth method
+// options production Vault deployments expect (mTLS, AppRole, Kubernetes service-account auth),
+// and, when the resulting Vault token is renewable, keeps it alive in the background (see
+// watchAndRenewToken).
+func NewCertManagerWithConfig(vaultConfig VaultConfig, cfg configurator.Configurator) (*CertManager, error) {
 	c := &CertManager{
 		announcements: make(chan announcements.Announcement),
-		role:          vaultRole(role),
+		role:          vaultRole(vaultConfig.Role),
 		cfg:           cfg,
+		vaultConfig:   vaultConfig,
 	}
+	c.cache = certificate.NewCache(vaultConfig.Cache, c.onCacheEvict)
+
 	config := api.DefaultConfig()
-	config.Address = vaultAddr
+	config.Address = vaultConfig.Address
+	if vaultConfig.TLS != nil {
+		tlsConfig := buildTLSConfig(vaultConfig.TLS)
+		if err := config.ConfigureTLS(&tlsConfig); err != nil {
+			return nil, errors.Wrapf(err, "error configuring TLS for Vault at %s", vaultConfig.Address)
+		}
+	}
 
 	var err error
 	if c.client, err = api.NewClient(config); err != nil {
-		return nil, errors.Errorf("Error creating Vault CertManager without TLS at %s", vaultAddr)
+		return nil, errors.Wrapf(err, "error creating Vault CertManager at %s", vaultConfig.Address)
 	}
 
-	log.Info().Msgf("Created Vault CertManager, with role=%q at %v", role, vaultAddr)
+	authSecret, err := authenticate(c.client, vaultConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error authenticating to Vault at %s", vaultConfig.Address)
+	}
+	c.watchAndRenewToken(authSecret)
 
-	c.client.SetToken(token)
+	log.Info().Msgf("Created Vault CertManager, with role=%q auth=%q at %v", vaultConfig.Role, vaultConfig.AuthMethod, vaultConfig.Address)
 
 	// Create a temp certificate to determine the issuing CA
 	tmpCert, err := c.issue("localhost", tmpCertValidityPeriod)
@@ -70,30 +109,129 @@ func NewCertManager(vaultAddr, token string, role string, cfg configurator.Confi
 }
 
 func (cm *CertManager) issue(cn certificate.CommonName, validityPeriod time.Duration) (certificate.Certificater, error) {
+	if cm.vaultConfig.KeyGenerationMode == KeyGenerationLocalCSR {
+		return cm.issueViaCSR(cn, validityPeriod)
+	}
+
 	secret, err := cm.client.Logical().Write(getIssueURL(cm.role).String(), getIssuanceData(cn, validityPeriod))
 	if err != nil {
 		log.Error().Err(err).Msgf("Error issuing new certificate for CN=%s", cn)
 		return nil, err
 	}
 
-	return newCert(cn, secret, time.Now().Add(validityPeriod)), nil
+	issued := time.Now()
+	return newCert(cn, secret, issued, issued.Add(validityPeriod), cm.currentRenewalWindowRatio()), nil
 }
 
 func (cm *CertManager) deleteFromCache(cn certificate.CommonName) {
-	cm.cache.Delete(cn)
+	cm.cache.Remove(cn)
 }
 
 func (cm *CertManager) getFromCache(cn certificate.CommonName) certificate.Certificater {
-	if certificateInterface, exists := cm.cache.Load(cn); exists {
-		cert := certificateInterface.(certificate.Certificater)
-		log.Trace().Msgf("Certificate found in cache CN=%s", cn)
-		if rotor.ShouldRotate(cert) {
-			log.Trace().Msgf("Certificate found in cache but has expired CN=%s", cn)
-			return nil
+	cert, exists := cm.cache.Load(cn)
+	if !exists {
+		return nil
+	}
+
+	log.Trace().Msgf("Certificate found in cache CN=%s", cn)
+	if rotor.ShouldRotate(cert) {
+		log.Trace().Msgf("Certificate found in cache but has expired CN=%s", cn)
+		return nil
+	}
+	return cert
+}
+
+// onCacheEvict is called by the certificate.Cache whenever it evicts an entry due to capacity or
+// TTL, so downstream watchers learn a certificate is gone even if nothing explicitly released it.
+func (cm *CertManager) onCacheEvict(cn certificate.CommonName, cert certificate.Certificater) {
+	log.Info().Msgf("Evicted certificate CN=%s from cache", cn)
+	metrics.CertExpirySeconds.DeleteLabelValues(string(cn), cert.GetSerialNumber(), issuerLabel)
+	metrics.CertCacheSize.Set(float64(cm.cache.Len()))
+	cm.announce(announcements.CertificateReleased, &announcements.CertificateEvent{CN: string(cn)})
+}
+
+// announce publishes a typed Announcement carrying event on cm.announcements.
+func (cm *CertManager) announce(kind announcements.Kind, event *announcements.CertificateEvent) {
+	cm.announcements <- announcements.Announcement{Type: kind, NewObj: event}
+}
+
+// announceRotated publishes a CertificateRotated announcement with OldObj set to previous's event,
+// as documented on announcements.CertificateRotated. previous is nil for a certificate's first
+// issuance, in which case OldObj is left nil too.
+func (cm *CertManager) announceRotated(cn certificate.CommonName, previous, cert certificate.Certificater) {
+	var old *announcements.CertificateEvent
+	if previous != nil {
+		old = &announcements.CertificateEvent{
+			CN:           string(cn),
+			SerialNumber: previous.GetSerialNumber(),
+			Expiration:   previous.GetExpiration(),
 		}
-		return cert
 	}
-	return nil
+	cm.announcements <- announcements.Announcement{
+		Type:   announcements.CertificateRotated,
+		OldObj: old,
+		NewObj: &announcements.CertificateEvent{
+			CN:           string(cn),
+			SerialNumber: cert.GetSerialNumber(),
+			Expiration:   cert.GetExpiration(),
+		},
+	}
+}
+
+// recordIssuanceMetrics updates the Prometheus metrics for a successful issuance, rotation, or
+// rekey of cn. previous, if non-nil, is the certificate cert is replacing; its now-stale
+// CertExpirySeconds series is deleted so the cardinality of that gauge tracks live certificates
+// rather than every serial number ever issued.
+func (cm *CertManager) recordIssuanceMetrics(cn certificate.CommonName, previous, cert certificate.Certificater, duration time.Duration) {
+	if previous != nil && previous.GetSerialNumber() != cert.GetSerialNumber() {
+		metrics.CertExpirySeconds.DeleteLabelValues(string(cn), previous.GetSerialNumber(), issuerLabel)
+	}
+	metrics.CertIssueDurationSeconds.WithLabelValues(issuerLabel).Observe(duration.Seconds())
+	metrics.CertExpirySeconds.WithLabelValues(string(cn), cert.GetSerialNumber(), issuerLabel).Set(float64(cert.GetExpiration().Unix()))
+	metrics.CertCacheSize.Set(float64(cm.cache.Len()))
+}
+
+// AnnounceNearExpiry implements rotor.NearExpiryAnnouncer, letting the rotor report certificates
+// that have entered their renewal window but haven't reached their jittered rotation instant yet.
+func (cm *CertManager) AnnounceNearExpiry(cert certificate.Certificater) {
+	cm.announce(announcements.CertificateNearExpiry, &announcements.CertificateEvent{
+		CN:           string(cert.GetCommonName()),
+		SerialNumber: cert.GetSerialNumber(),
+		Expiration:   cert.GetExpiration(),
+	})
+}
+
+// SetCacheOptions updates the certificate cache's capacity, eviction policy, and TTL at runtime,
+// without requiring a control plane restart.
+func (cm *CertManager) SetCacheOptions(options certificate.CacheOptions) {
+	cm.cache.SetOptions(options)
+}
+
+// SetRenewalWindowRatio updates the fraction of a certificate's lifetime, counting back from
+// expiry, during which it is eligible for rotation. It takes effect for certificates issued after
+// the call; already-issued certificates keep the renewal window computed for them at issuance.
+// While cfg reports a positive renewal ratio, that value is used instead and this one is ignored.
+func (cm *CertManager) SetRenewalWindowRatio(ratio float64) {
+	cm.renewalWindowRatioMu.Lock()
+	defer cm.renewalWindowRatioMu.Unlock()
+	cm.renewalWindowRatio = ratio
+}
+
+// currentRenewalWindowRatio returns the renewal window ratio new certificates are issued with,
+// preferring a live value from cfg so operators can tune it the same way they tune every other
+// OSM config knob -- through the configurator's hot reload -- rather than only through the
+// explicit SetRenewalWindowRatio override, which remains for callers that construct a CertManager
+// without a configurator.
+func (cm *CertManager) currentRenewalWindowRatio() float64 {
+	if cm.cfg != nil {
+		if ratio := cm.cfg.GetCertificateRenewalRatio(); ratio > 0 {
+			return ratio
+		}
+	}
+
+	cm.renewalWindowRatioMu.RLock()
+	defer cm.renewalWindowRatioMu.RUnlock()
+	return cm.renewalWindowRatio
 }
 
 // IssueCertificate issues a certificate by leveraging the Hashi Vault CertManager.
@@ -106,31 +244,46 @@ func (cm *CertManager) IssueCertificate(cn certificate.CommonName, validityPerio
 		return cert, nil
 	}
 
+	previous, _ := cm.cache.Load(cn)
+
 	cert, err := cm.issue(cn, validityPeriod)
 	if err != nil {
+		metrics.CertIssueFailuresTotal.WithLabelValues(issuerLabel, "issue-error").Inc()
 		return cert, err
 	}
 
 	cm.cache.Store(cn, cert)
 
-	log.Info().Msgf("Issuing new certificate for CN=%s took %+v", cn, time.Since(start))
+	duration := time.Since(start)
+	cm.recordIssuanceMetrics(cn, previous, cert, duration)
+	if previous != nil {
+		cm.announceRotated(cn, previous, cert)
+	} else {
+		cm.announce(announcements.CertificateIssued, &announcements.CertificateEvent{
+			CN:           string(cn),
+			SerialNumber: cert.GetSerialNumber(),
+			Expiration:   cert.GetExpiration(),
+		})
+	}
+
+	log.Info().Msgf("Issuing new certificate for CN=%s took %+v", cn, duration)
 
 	return cert, nil
 }
 
 // ReleaseCertificate is called when a cert will no longer be needed and should be removed from the system.
 func (cm *CertManager) ReleaseCertificate(cn certificate.CommonName) {
+	if cert, exists := cm.cache.Load(cn); exists {
+		metrics.CertExpirySeconds.DeleteLabelValues(string(cn), cert.GetSerialNumber(), issuerLabel)
+	}
 	cm.deleteFromCache(cn)
+	metrics.CertCacheSize.Set(float64(cm.cache.Len()))
+	cm.announce(announcements.CertificateReleased, &announcements.CertificateEvent{CN: string(cn)})
 }
 
 // ListCertificates lists all certificates issued
 func (cm *CertManager) ListCertificates() ([]certificate.Certificater, error) {
-	var certs []certificate.Certificater
-	cm.cache.Range(func(cnInterface interface{}, certInterface interface{}) bool {
-		certs = append(certs, certInterface.(certificate.Certificater))
-		return true
-	})
-	return certs, nil
+	return cm.cache.List(), nil
 }
 
 // GetCertificate returns a certificate given its Common Name (CN)
@@ -153,23 +306,76 @@ func (cm *CertManager) GetAnnouncementsChannel() <-chan announcements.Announceme
 
 // RotateCertificate implements certificate.Manager and rotates an existing certificate.
 func (cm *CertManager) RotateCertificate(cn certificate.CommonName) (certificate.Certificater, error) {
+	if wait, ok := cm.backoffRemaining(cn); ok {
+		log.Trace().Msgf("Skipping rotation for CN=%s, backing off for %s after a previous failure", cn, wait)
+		return nil, errors.Errorf("rotation for CN=%s is backing off for %s", cn, wait)
+	}
+
 	log.Info().Msgf("Rotating certificate for CN=%s", cn)
 
 	start := time.Now()
+	previous, _ := cm.cache.Load(cn)
 
 	cert, err := cm.issue(cn, cm.cfg.GetServiceCertValidityPeriod())
 	if err != nil {
+		cm.recordIssueFailure(cn)
+		metrics.CertIssueFailuresTotal.WithLabelValues(issuerLabel, "rotate-error").Inc()
+		event := &announcements.CertificateEvent{CN: string(cn)}
+		if previous != nil {
+			event.SerialNumber = previous.GetSerialNumber()
+			event.Expiration = previous.GetExpiration()
+		}
+		cm.announce(announcements.CertificateRotationFailed, event)
 		return cert, err
 	}
+	cm.retryState.Delete(cn)
 
 	cm.cache.Store(cn, cert)
-	cm.announcements <- announcements.Announcement{}
 
-	log.Info().Msgf("Rotating certificate CN=%s took %+v", cn, time.Since(start))
+	duration := time.Since(start)
+	cm.recordIssuanceMetrics(cn, previous, cert, duration)
+	cm.announceRotated(cn, previous, cert)
+
+	log.Info().Msgf("Rotating certificate CN=%s took %+v", cn, duration)
 
 	return cert, nil
 }
 
+// issueRetryState tracks exponential backoff for a CN whose issuance has been failing.
+type issueRetryState struct {
+	attempts    int
+	nextAttempt time.Time
+}
+
+// backoffRemaining reports whether CN is still within a backoff window from a previous issuance
+// failure, and if so, how much longer it must wait.
+func (cm *CertManager) backoffRemaining(cn certificate.CommonName) (time.Duration, bool) {
+	stateInterface, exists := cm.retryState.Load(cn)
+	if !exists {
+		return 0, false
+	}
+	state := stateInterface.(*issueRetryState)
+	if remaining := time.Until(state.nextAttempt); remaining > 0 {
+		return remaining, true
+	}
+	return 0, false
+}
+
+// recordIssueFailure bumps CN's retry counter and schedules its next allowed attempt using
+// exponential backoff with jitter.
+func (cm *CertManager) recordIssueFailure(cn certificate.CommonName) {
+	attempts := 0
+	if stateInterface, exists := cm.retryState.Load(cn); exists {
+		attempts = stateInterface.(*issueRetryState).attempts
+	}
+	attempts++
+
+	cm.retryState.Store(cn, &issueRetryState{
+		attempts:    attempts,
+		nextAttempt: time.Now().Add(rotor.Backoff(attempts-1, issueBackoffBase, issueBackoffMax)),
+	})
+}
+
 // Certificate implements certificate.Certificater
 type Certificate struct {
 	// The commonName of the certificate
@@ -187,6 +393,23 @@ type Certificate struct {
 
 	// serialNumber is the serial_number value in the Data field assigned to the Certificate Hashicorp Vault issued
 	serialNumber string
+
+	// windowStart and nextRotation bound this certificate's ARI-style renewal window; nextRotation
+	// is the deterministic instant inside it at which the certificate should be rotated. Both are
+	// computed once at issuance time so the rotor's inner loop can check them without recomputing
+	// a window on every poll.
+	windowStart  time.Time
+	nextRotation time.Time
+}
+
+// NextRotationTime implements rotor.RenewalScheduler.
+func (c Certificate) NextRotationTime() time.Time {
+	return c.nextRotation
+}
+
+// RenewalWindowStart implements rotor.RenewalScheduler.
+func (c Certificate) RenewalWindowStart() time.Time {
+	return c.windowStart
 }
 
 // GetCommonName returns the common name of the given certificate.
@@ -214,18 +437,38 @@ func (c Certificate) GetExpiration() time.Time {
 	return c.expiration
 }
 
-func newCert(cn certificate.CommonName, secret *api.Secret, expiration time.Time) *Certificate {
+func newCert(cn certificate.CommonName, secret *api.Secret, issued, expiration time.Time, renewalWindowRatio float64) *Certificate {
+	return newCertFromSecret(cn, secret, []byte(secret.Data[privateKeyField].(string)), issued, expiration, renewalWindowRatio)
+}
+
+// GetSerialNumber returns the serial number of the given certificate.
+func (c Certificate) GetSerialNumber() string {
+	return c.serialNumber
+}
+
+// newCertFromSignedCSR builds a Certificate from the response to a Vault pki/sign request. Unlike
+// newCert, the private key comes from privateKeyPEM (generated locally by OSM) rather than from
+// the Vault response, which never carries a private_key field for sign requests.
+func newCertFromSignedCSR(cn certificate.CommonName, secret *api.Secret, privateKeyPEM pem.PrivateKey, issued, expiration time.Time, renewalWindowRatio float64) *Certificate {
+	return newCertFromSecret(cn, secret, privateKeyPEM, issued, expiration, renewalWindowRatio)
+}
+
+// newCertFromSecret builds a Certificate from a Vault issue/sign response shared by both
+// newCert and newCertFromSignedCSR; privateKey is supplied separately since only the issue
+// response (not the sign response) carries one of its own.
+func newCertFromSecret(cn certificate.CommonName, secret *api.Secret, privateKey pem.PrivateKey, issued, expiration time.Time, renewalWindowRatio float64) *Certificate {
+	serialNumber := secret.Data[serialNumberField].(string)
+	windowStart, windowEnd := rotor.RenewalWindow(issued, expiration, renewalWindowRatio)
+
 	return &Certificate{
 		commonName:   cn,
 		expiration:   expiration,
 		certChain:    pem.Certificate(secret.Data[certificateField].(string)),
-		privateKey:   []byte(secret.Data[privateKeyField].(string)),
+		privateKey:   privateKey,
 		issuingCA:    pem.RootCertificate(secret.Data[issuingCAField].(string)),
-		serialNumber: secret.Data[serialNumberField].(string),
+		serialNumber: serialNumber,
+		windowStart:  windowStart,
+		nextRotation: rotor.PickRenewalTime(windowStart, windowEnd, serialNumber),
 	}
 }
 
-// GetSerialNumber returns the serial number of the given certificate.
-func (c Certificate) GetSerialNumber() string {
-	return c.serialNumber
-}
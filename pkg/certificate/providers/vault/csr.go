@@ -0,0 +1,147 @@
+package vault
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/metrics"
+	certificatepem "github.com/openservicemesh/osm/pkg/certificate/pem"
+)
+
+// KeyGenerationMode selects who generates the certificate's private key.
+type KeyGenerationMode string
+
+const (
+	// KeyGenerationVault has Vault generate the keypair and return the private key in the issue
+	// response. This is the default, and the original behavior of this package.
+	KeyGenerationVault KeyGenerationMode = "vault-generated"
+
+	// KeyGenerationLocalCSR has OSM generate the keypair locally, build a CSR for the requested
+	// CN, and have Vault's PKI sign endpoint sign it. The private key never leaves OSM.
+	KeyGenerationLocalCSR KeyGenerationMode = "local-csr"
+)
+
+// KeyAlgorithm selects the key type OSM generates for KeyGenerationLocalCSR.
+type KeyAlgorithm string
+
+const (
+	// KeyAlgorithmECDSA generates ECDSA P-256 keys.
+	KeyAlgorithmECDSA KeyAlgorithm = "ecdsa-p256"
+
+	// KeyAlgorithmRSA generates RSA-2048 keys.
+	KeyAlgorithmRSA KeyAlgorithm = "rsa-2048"
+)
+
+// generateKey creates a new private key using the given algorithm, defaulting to ECDSA P-256.
+func generateKey(alg KeyAlgorithm) (crypto.Signer, error) {
+	switch alg {
+	case KeyAlgorithmRSA:
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, errors.Wrap(err, "error generating RSA-2048 key")
+		}
+		return key, nil
+
+	case KeyAlgorithmECDSA, "":
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "error generating ECDSA P-256 key")
+		}
+		return key, nil
+
+	default:
+		return nil, errors.Errorf("vault: unsupported key algorithm %q", alg)
+	}
+}
+
+// marshalPrivateKeyPEM PEM-encodes a private key in PKCS#8 form.
+func marshalPrivateKeyPEM(key crypto.Signer) (certificatepem.PrivateKey, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling private key")
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// buildCSRPEM builds a PEM-encoded PKCS#10 CSR for cn, signed by key.
+func buildCSRPEM(key crypto.Signer, cn certificate.CommonName) ([]byte, error) {
+	template := &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: string(cn)},
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating CSR")
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), nil
+}
+
+func getSignURL(role vaultRole) string {
+	return fmt.Sprintf("pki/sign/%s", role)
+}
+
+// issueViaCSR generates a local keypair, builds a CSR for cn, and has Vault sign it via the PKI
+// sign endpoint. Unlike issue, the private key never travels over the wire to or from Vault.
+func (cm *CertManager) issueViaCSR(cn certificate.CommonName, validityPeriod time.Duration) (certificate.Certificater, error) {
+	key, err := generateKey(cm.vaultConfig.KeyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	csrPEM, err := buildCSRPEM(key, cn)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKeyPEM, err := marshalPrivateKeyPEM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data := getIssuanceData(cn, validityPeriod)
+	data["csr"] = string(csrPEM)
+
+	secret, err := cm.client.Logical().Write(getSignURL(cm.role), data)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error signing CSR for CN=%s", cn)
+		return nil, err
+	}
+
+	issued := time.Now()
+	return newCertFromSignedCSR(cn, secret, privateKeyPEM, issued, issued.Add(validityPeriod), cm.currentRenewalWindowRatio()), nil
+}
+
+// RekeyCertificate generates a fresh keypair and CSR for cn, preserving its common name, and has
+// Vault sign the new CSR -- analogous to the Rekey operation in step-ca. It always uses
+// KeyGenerationLocalCSR semantics, regardless of CertManager's configured KeyGenerationMode,
+// since the whole point of a rekey is to stop trusting a previously-issued key.
+func (cm *CertManager) RekeyCertificate(cn certificate.CommonName) (certificate.Certificater, error) {
+	log.Info().Msgf("Rekeying certificate for CN=%s", cn)
+
+	start := time.Now()
+	previous, _ := cm.cache.Load(cn)
+
+	cert, err := cm.issueViaCSR(cn, cm.cfg.GetServiceCertValidityPeriod())
+	if err != nil {
+		metrics.CertIssueFailuresTotal.WithLabelValues(issuerLabel, "rekey-error").Inc()
+		return nil, err
+	}
+
+	cm.cache.Store(cn, cert)
+	cm.recordIssuanceMetrics(cn, previous, cert, time.Since(start))
+	cm.announceRotated(cn, previous, cert)
+
+	return cert, nil
+}
@@ -0,0 +1,20 @@
+package vault
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+)
+
+func getIssueURL(role vaultRole) *url.URL {
+	return &url.URL{Path: fmt.Sprintf("pki/issue/%s", role)}
+}
+
+func getIssuanceData(cn certificate.CommonName, validityPeriod time.Duration) map[string]interface{} {
+	return map[string]interface{}{
+		commonNameField: cn,
+		ttlField:        validityPeriod.Seconds(),
+	}
+}
@@ -0,0 +1,157 @@
+package vault
+
+import (
+	"sync"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+// vaultRole is the name of the Vault PKI role certificates are issued and signed under.
+type vaultRole string
+
+func (r vaultRole) String() string {
+	return string(r)
+}
+
+var errCertNotFound = errors.New("certificate not found")
+
+// AuthMethod identifies how CertManager authenticates to Vault.
+type AuthMethod string
+
+const (
+	// AuthMethodToken authenticates with a static, pre-issued Vault token.
+	AuthMethodToken AuthMethod = "token"
+
+	// AuthMethodAppRole authenticates using the Vault AppRole auth method (role_id + secret_id).
+	AuthMethodAppRole AuthMethod = "approle"
+
+	// AuthMethodKubernetes authenticates using the Vault Kubernetes auth method, presenting the
+	// pod's projected service-account JWT to Vault.
+	AuthMethodKubernetes AuthMethod = "kubernetes"
+)
+
+// TLSConfig configures the TLS transport CertManager uses to talk to Vault.
+type TLSConfig struct {
+	// CACertPEM is the PEM-encoded CA bundle used to verify the Vault server certificate.
+	CACertPEM []byte
+
+	// ClientCertPEM and ClientKeyPEM configure mTLS between OSM and Vault.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+
+	// ServerName overrides the SNI/certificate name expected from Vault.
+	ServerName string
+
+	// InsecureSkipVerify disables verification of the Vault server certificate. Never set this
+	// in production; it exists for local development against a self-signed Vault dev server.
+	InsecureSkipVerify bool
+}
+
+// AppRoleAuth holds the credentials used by AuthMethodAppRole.
+type AppRoleAuth struct {
+	RoleID   string
+	SecretID string
+
+	// MountPath is the path the AppRole auth method is mounted at. Defaults to "approle".
+	MountPath string
+}
+
+// KubernetesAuth holds the credentials used by AuthMethodKubernetes.
+type KubernetesAuth struct {
+	// Role is the Vault Kubernetes auth role bound to OSM's service account.
+	Role string
+
+	// MountPath is the path the Kubernetes auth method is mounted at. Defaults to "kubernetes".
+	MountPath string
+
+	// ServiceAccountTokenPath is where the pod's projected service-account JWT can be read from.
+	// Defaults to defaultServiceAccountTokenPath.
+	ServiceAccountTokenPath string
+}
+
+// VaultConfig describes how to connect and authenticate to a Hashi Vault instance.
+type VaultConfig struct {
+	// Address is the Vault server address, e.g. https://vault.default.svc:8200.
+	Address string
+
+	// Role is the Vault PKI role certificates are issued under.
+	Role string
+
+	// AuthMethod selects which of the fields below CertManager authenticates with.
+	// Defaults to AuthMethodToken when empty.
+	AuthMethod AuthMethod
+
+	// Token is used when AuthMethod is AuthMethodToken.
+	Token string
+
+	// AppRole is used when AuthMethod is AuthMethodAppRole.
+	AppRole *AppRoleAuth
+
+	// Kubernetes is used when AuthMethod is AuthMethodKubernetes.
+	Kubernetes *KubernetesAuth
+
+	// TLS configures the transport used to reach Vault. A nil value leaves the TLS settings of
+	// api.DefaultConfig unchanged.
+	TLS *TLSConfig
+
+	// Cache configures the bounded certificate cache. Zero value uses certificate.DefaultCacheCapacity
+	// with LRU eviction and no TTL.
+	Cache certificate.CacheOptions
+
+	// KeyGenerationMode selects who generates each certificate's private key. Defaults to
+	// KeyGenerationVault, matching this package's original behavior.
+	KeyGenerationMode KeyGenerationMode
+
+	// KeyAlgorithm selects the key type OSM generates when KeyGenerationMode is
+	// KeyGenerationLocalCSR. Defaults to KeyAlgorithmECDSA.
+	KeyAlgorithm KeyAlgorithm
+}
+
+// CertManager implements certificate.Manager and wraps a Hashi Vault with methods to allow easy certificate issuance.
+type CertManager struct {
+	client        *api.Client
+	cache         *certificate.Cache
+	role          vaultRole
+	cfg           configurator.Configurator
+	ca            *Certificate
+	announcements chan announcements.Announcement
+
+	// vaultConfig is retained so the background token-renewal goroutine can re-authenticate
+	// using the same auth method once the current Vault lease can no longer be renewed.
+	vaultConfig VaultConfig
+
+	// renewalWindowRatioMu guards renewalWindowRatio so SetRenewalWindowRatio can be called
+	// concurrently with issuance.
+	renewalWindowRatioMu sync.RWMutex
+
+	// renewalWindowRatio is the fraction of a certificate's lifetime, counting back from expiry,
+	// during which it becomes eligible for rotation. Defaults to rotor.defaultRenewalWindowRatio
+	// when zero. See pkg/certificate/rotor for the window and jitter computation. Read and written
+	// through currentRenewalWindowRatio() and SetRenewalWindowRatio, never accessed directly.
+	renewalWindowRatio float64
+
+	// retryState tracks per-CN issuance backoff after a failed rotation, keyed by
+	// certificate.CommonName, so a Vault outage doesn't produce a thundering herd of retries.
+	retryState sync.Map
+}
+
+// buildTLSConfig translates TLSConfig into the shape api.Config expects.
+func buildTLSConfig(tls *TLSConfig) api.TLSConfig {
+	if tls == nil {
+		return api.TLSConfig{}
+	}
+	return api.TLSConfig{
+		CACertBytes:   tls.CACertPEM,
+		ClientCert:    string(tls.ClientCertPEM),
+		ClientKey:     string(tls.ClientKeyPEM),
+		TLSServerName: tls.ServerName,
+		Insecure:      tls.InsecureSkipVerify,
+	}
+}
+
+const defaultServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
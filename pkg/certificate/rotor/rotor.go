@@ -0,0 +1,212 @@
+// Package rotor implements background certificate rotation for certificate.Manager
+// implementations.
+package rotor
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var log = logger.New("rotor")
+
+const (
+	// defaultRenewalWindowRatio is the fraction of a certificate's lifetime, counting back from
+	// expiry, during which it becomes eligible for rotation. It mirrors the default suggested by
+	// the ACME Renewal Information (ARI) draft.
+	defaultRenewalWindowRatio = 1.0 / 3.0
+
+	// pollJitterFraction is the fraction of the Start interval by which each sleep between checks
+	// is randomly lengthened or shortened. Because RenewalScheduler certificates cache their own
+	// next-attempt instant, each check is O(1) per certificate regardless of how often it runs.
+	pollJitterFraction = 0.1
+)
+
+// Rotor periodically checks every certificate held by a certificate.Manager and rotates the ones
+// that have entered their renewal window.
+type Rotor struct {
+	manager certificate.Manager
+
+	// announced tracks, by CN, the serial number of the certificate a near-expiry announcement was
+	// last sent for, so AnnounceNearExpiry fires once per certificate per renewal window instead of
+	// on every poll tick -- a window is typically hours wide, polled every few seconds.
+	announced sync.Map
+}
+
+// New returns a Rotor that rotates certificates issued by the given manager.
+func New(manager certificate.Manager) *Rotor {
+	return &Rotor{manager: manager}
+}
+
+// Start begins the rotation loop in a background goroutine, waking up roughly every interval
+// (plus a small jitter, so a fleet of proxies started at the same time doesn't all wake in
+// lock-step) to rotate any certificate whose renewal window has opened.
+func (r *Rotor) Start(interval time.Duration) {
+	go func() {
+		for {
+			time.Sleep(jittered(interval, pollJitterFraction))
+			r.rotateDue()
+		}
+	}()
+}
+
+func (r *Rotor) rotateDue() {
+	certs, err := r.manager.ListCertificates()
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing certificates for rotation")
+		return
+	}
+
+	announcer, _ := r.manager.(NearExpiryAnnouncer)
+
+	for _, cert := range certs {
+		if ShouldRotate(cert) {
+			cn := cert.GetCommonName()
+			log.Info().Msgf("Certificate CN=%s entered its renewal window; rotating", cn)
+			if _, err := r.manager.RotateCertificate(cn); err != nil {
+				log.Error().Err(err).Msgf("Error rotating certificate CN=%s", cn)
+			}
+			continue
+		}
+
+		if announcer != nil && isNearExpiry(cert) {
+			r.announceNearExpiryOnce(announcer, cert)
+		}
+	}
+}
+
+// announceNearExpiryOnce calls AnnounceNearExpiry the first time a given certificate (identified
+// by CN and serial number) is observed near expiry, and is a no-op on every subsequent poll tick
+// inside the same renewal window.
+func (r *Rotor) announceNearExpiryOnce(announcer NearExpiryAnnouncer, cert certificate.Certificater) {
+	cn := cert.GetCommonName()
+	serial := cert.GetSerialNumber()
+
+	if last, ok := r.announced.Load(cn); ok && last == serial {
+		return
+	}
+
+	announcer.AnnounceNearExpiry(cert)
+	r.announced.Store(cn, serial)
+}
+
+// isNearExpiry reports whether a certificate has entered its renewal window but hasn't reached
+// its jittered rotation instant yet.
+func isNearExpiry(cert certificate.Certificater) bool {
+	scheduled, ok := cert.(RenewalScheduler)
+	if !ok {
+		return false
+	}
+	now := time.Now()
+	return !now.Before(scheduled.RenewalWindowStart()) && now.Before(scheduled.NextRotationTime())
+}
+
+// RenewalScheduler is implemented by certificates that can precompute their own next rotation
+// instant. Providers implement it so the rotor's inner loop stays O(1) per certificate instead of
+// recomputing a renewal window on every poll.
+type RenewalScheduler interface {
+	// NextRotationTime returns the deterministic instant, inside this certificate's renewal
+	// window, at which it should be rotated.
+	NextRotationTime() time.Time
+
+	// RenewalWindowStart returns the start of this certificate's renewal window, i.e. the
+	// earliest instant at which it is eligible for rotation.
+	RenewalWindowStart() time.Time
+}
+
+// NearExpiryAnnouncer is implemented by certificate.Manager implementations that want to be told
+// when a certificate has entered its renewal window but hasn't reached its jittered rotation
+// instant yet.
+type NearExpiryAnnouncer interface {
+	AnnounceNearExpiry(certificate.Certificater)
+}
+
+// ShouldRotate returns true if a certificate has entered its renewal window and should be
+// rotated now. Certificates implementing RenewalScheduler are checked against their cached,
+// pre-computed rotation instant; others fall back to a simple expiry-threshold check.
+func ShouldRotate(cert certificate.Certificater) bool {
+	if scheduled, ok := cert.(RenewalScheduler); ok {
+		return !time.Now().Before(scheduled.NextRotationTime())
+	}
+	return !time.Now().Before(cert.GetExpiration())
+}
+
+// RenewalWindow computes the ARI-style renewal window for a certificate with the given issuance
+// time, expiry, and renewal ratio: [expiry - lifetime*ratio, expiry - lifetime*(ratio/2)]. ratio is
+// clamped to (0, 1]: above 1, lifetime*ratio would exceed the certificate's actual lifetime, so
+// start would precede issued and the certificate would be eligible for rotation (and re-rotation,
+// forever) from the moment it's issued -- the exact rotation storm this window model exists to
+// prevent.
+func RenewalWindow(issued, expiry time.Time, ratio float64) (start, end time.Time) {
+	switch {
+	case ratio <= 0:
+		ratio = defaultRenewalWindowRatio
+	case ratio > 1:
+		ratio = 1
+	}
+
+	lifetime := expiry.Sub(issued)
+	start = expiry.Add(-time.Duration(float64(lifetime) * ratio))
+	end = expiry.Add(-time.Duration(float64(lifetime) * ratio / 2))
+	if start.Before(issued) {
+		start = issued
+	}
+	if end.Before(start) {
+		end = start
+	}
+	return start, end
+}
+
+// PickRenewalTime deterministically picks an instant inside [start, end), seeded by the
+// certificate's serial number. Seeding on the serial (rather than using global randomness) means
+// every replica of a control plane computes the same renewal instant for the same certificate,
+// and repeated calls are idempotent.
+func PickRenewalTime(start, end time.Time, serialNumber string) time.Time {
+	window := end.Sub(start)
+	if window <= 0 {
+		return start
+	}
+	src := rand.New(rand.NewSource(seedFromSerial(serialNumber))) // #nosec G404 -- deterministic jitter, not security sensitive
+	offset := time.Duration(src.Int63n(int64(window)))
+	return start.Add(offset)
+}
+
+func seedFromSerial(serialNumber string) int64 {
+	var seed int64
+	for _, b := range []byte(serialNumber) {
+		seed = seed*31 + int64(b)
+	}
+	if seed < 0 {
+		seed = -seed
+	}
+	return seed
+}
+
+// jittered returns d plus or minus a random fraction of d, so periodic loops across a fleet
+// don't stay synchronized.
+func jittered(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return d
+	}
+	delta := time.Duration(float64(d) * fraction)
+	// #nosec G404 -- jitter for poll scheduling, not security sensitive
+	return d - delta + time.Duration(rand.Int63n(int64(2*delta+1)))
+}
+
+// Backoff computes an exponential backoff duration with full jitter for the given attempt count
+// (0-indexed), capped at max. It is used to space out retries of failed certificate issuance so a
+// Vault outage doesn't produce a thundering herd of retries.
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	if attempt < 0 {
+		attempt = 0
+	}
+	capped := base << uint(attempt)
+	if capped <= 0 || capped > max {
+		capped = max
+	}
+	// #nosec G404 -- jitter for retry scheduling, not security sensitive
+	return time.Duration(rand.Int63n(int64(capped) + 1))
+}
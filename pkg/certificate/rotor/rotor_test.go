@@ -0,0 +1,107 @@
+package rotor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenewalWindow(t *testing.T) {
+	testCases := []struct {
+		name          string
+		issued        time.Time
+		expiry        time.Time
+		ratio         float64
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		{
+			name:          "one third ratio over a 30 day lifetime",
+			issued:        time.Unix(0, 0),
+			expiry:        time.Unix(0, 0).Add(30 * 24 * time.Hour),
+			ratio:         1.0 / 3.0,
+			expectedStart: time.Unix(0, 0).Add(20 * 24 * time.Hour),
+			expectedEnd:   time.Unix(0, 0).Add(25 * 24 * time.Hour),
+		},
+		{
+			name:          "zero ratio falls back to the default",
+			issued:        time.Unix(0, 0),
+			expiry:        time.Unix(0, 0).Add(30 * 24 * time.Hour),
+			ratio:         0,
+			expectedStart: time.Unix(0, 0).Add(20 * 24 * time.Hour),
+			expectedEnd:   time.Unix(0, 0).Add(25 * 24 * time.Hour),
+		},
+		{
+			name:          "negative ratio falls back to the default",
+			issued:        time.Unix(0, 0),
+			expiry:        time.Unix(0, 0).Add(30 * 24 * time.Hour),
+			ratio:         -1,
+			expectedStart: time.Unix(0, 0).Add(20 * 24 * time.Hour),
+			expectedEnd:   time.Unix(0, 0).Add(25 * 24 * time.Hour),
+		},
+		{
+			name:          "ratio above 1 is clamped to 1, so start never precedes issued",
+			issued:        time.Unix(0, 0),
+			expiry:        time.Unix(0, 0).Add(30 * 24 * time.Hour),
+			ratio:         2,
+			expectedStart: time.Unix(0, 0),
+			expectedEnd:   time.Unix(0, 0).Add(15 * 24 * time.Hour),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start, end := RenewalWindow(tc.issued, tc.expiry, tc.ratio)
+			assert.Equal(t, tc.expectedStart, start)
+			assert.Equal(t, tc.expectedEnd, end)
+			assert.False(t, end.Before(start))
+		})
+	}
+}
+
+func TestPickRenewalTime(t *testing.T) {
+	start := time.Unix(0, 0)
+	end := start.Add(5 * 24 * time.Hour)
+
+	t.Run("deterministic for the same serial number", func(t *testing.T) {
+		first := PickRenewalTime(start, end, "00:11:22:33")
+		second := PickRenewalTime(start, end, "00:11:22:33")
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("falls inside the window", func(t *testing.T) {
+		picked := PickRenewalTime(start, end, "aa:bb:cc:dd")
+		assert.False(t, picked.Before(start))
+		assert.True(t, picked.Before(end))
+	})
+
+	t.Run("different serials can pick different instants", func(t *testing.T) {
+		a := PickRenewalTime(start, end, "serial-a")
+		b := PickRenewalTime(start, end, "serial-b")
+		assert.NotEqual(t, a, b)
+	})
+
+	t.Run("empty window returns start", func(t *testing.T) {
+		assert.Equal(t, start, PickRenewalTime(start, start, "serial"))
+		assert.Equal(t, end, PickRenewalTime(end, start, "serial"))
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	base := 1 * time.Second
+	max := 1 * time.Minute
+
+	t.Run("never exceeds max, even for a large attempt count that would overflow", func(t *testing.T) {
+		for _, attempt := range []int{0, 1, 2, 10, 62, 63, 64, 1000} {
+			d := Backoff(attempt, base, max)
+			assert.GreaterOrEqual(t, d, time.Duration(0))
+			assert.LessOrEqual(t, d, max)
+		}
+	})
+
+	t.Run("negative attempt is treated as zero", func(t *testing.T) {
+		d := Backoff(-5, base, max)
+		assert.LessOrEqual(t, d, base)
+	})
+}